@@ -7,6 +7,10 @@
 //	clog.Success("Server started on port %d", 8080)
 //	clog.Error("Failed to connect: %v", err)
 //
+// Structured usage:
+//
+//	clog.WithField("user", "jane").WithError(err).Error("login failed")
+//
 // Log Levels (from lowest to highest):
 //   - PanicLevel: System is unusable, halts execution
 //   - ErrorLevel: Error events that might still allow the application to continue running
@@ -20,6 +24,17 @@
 //	clog.SetLogLevel(clog.DebugLevel)
 //	clog.SetShowFileLine(true)
 //	clog.SetShowGoroutineID(true)
+//	clog.SetFormatter(clog.FormatterJSON)
+//
+// Output routing:
+//
+//	clog.AddSink(clog.NewRotatingFileSink("app.log", 100, 7, 5), clog.DebugLevel)
+//
+// By default, clog writes colored text to stderr through a single built-in
+// sink. AddSink/RemoveSink layer additional destinations (files, plain
+// writers, async buffers) on top, each with its own minimum level; SetOutput
+// replaces every registered sink (the default one and any added ones) with a
+// single plain writer sink, similar to the standard log package.
 //
 // Features:
 //   - Colored output using emoji prefixes
@@ -29,13 +44,27 @@
 //   - Performance metrics logging
 //   - Multiple log levels with filtering
 //   - Panic handling with stack traces
+//   - Structured fields via WithField/WithFields/WithError
+//   - JSON output mode for log-shipping environments
+//   - Pluggable sinks: stderr, io.Writer, rotating file, async buffering
+//   - Scoped Logger values with context propagation and OTel trace IDs
+//   - Sampling and per-level rate limiting to survive log storms
+//   - Trace for timing operations, GORM-logger style
+//   - Hooks for forwarding Error/Panic entries (with stack traces) to
+//     external systems
 package clog
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
 	"path/filepath"
+	"regexp"
 	"runtime"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/fatih/color"
@@ -80,10 +109,23 @@ const (
 	TraceLevel
 )
 
+// Formatter selects how a log line is rendered.
+type Formatter int
+
+const (
+	// FormatterText renders colored, human-friendly lines (the default).
+	FormatterText Formatter = iota
+
+	// FormatterJSON renders each line as a single JSON object, suitable
+	// for containerized/log-shipping environments.
+	FormatterJSON
+)
+
 var (
-	currentLogLevel = InfoLevel
-	showFileLine    = true
-	showGoroutineID = true
+	currentLogLevel  = InfoLevel
+	showFileLine     = true
+	showGoroutineID  = true
+	currentFormatter = FormatterText
 )
 
 // SetLogLevel sets the current logging level
@@ -101,6 +143,94 @@ func SetShowGoroutineID(show bool) {
 	showGoroutineID = show
 }
 
+// SetFormatter selects between colored text output (FormatterText) and
+// single-line JSON output (FormatterJSON).
+func SetFormatter(f Formatter) {
+	currentFormatter = f
+}
+
+// Fields is a set of structured key/value pairs attached to a log line.
+type Fields map[string]interface{}
+
+// Entry carries fields and an optional error across to the eventual
+// Info/Debug/Error/... call, similar to logrus/zap. Create one with
+// WithField, WithFields, or WithError. Once dispatched, an Entry also
+// doubles as the record handed to every registered Sink.
+type Entry struct {
+	fields Fields
+	err    error
+
+	// Populated when the entry is finalized for output.
+	Time          time.Time
+	Level         LogLevel
+	LevelName     string
+	Prefix        string
+	Message       string
+	FileInfo      string
+	GoroutineInfo string
+}
+
+// WithField starts a new Entry carrying a single structured field.
+func WithField(key string, value interface{}) *Entry {
+	return &Entry{fields: Fields{key: value}}
+}
+
+// WithFields starts a new Entry carrying the given structured fields.
+func WithFields(fields Fields) *Entry {
+	e := &Entry{fields: make(Fields, len(fields))}
+	for k, v := range fields {
+		e.fields[k] = v
+	}
+	return e
+}
+
+// WithError starts a new Entry carrying err, merged into the line as an
+// "error" field.
+func WithError(err error) *Entry {
+	return &Entry{fields: Fields{}, err: err}
+}
+
+// WithField adds a structured field to the Entry and returns it for chaining.
+func (e *Entry) WithField(key string, value interface{}) *Entry {
+	e.fields[key] = value
+	return e
+}
+
+// WithFields merges the given structured fields into the Entry and returns
+// it for chaining.
+func (e *Entry) WithFields(fields Fields) *Entry {
+	for k, v := range fields {
+		e.fields[k] = v
+	}
+	return e
+}
+
+// WithError attaches err to the Entry and returns it for chaining.
+func (e *Entry) WithError(err error) *Entry {
+	e.err = err
+	return e
+}
+
+// Fields returns the structured fields attached to the entry, including the
+// merged "error" field when WithError was used.
+func (e Entry) Fields() Fields {
+	return e.mergedFields()
+}
+
+// mergedFields returns the Entry's fields with a merged-in "error" key when
+// an error has been attached.
+func (e *Entry) mergedFields() Fields {
+	if e.err == nil {
+		return e.fields
+	}
+	fields := make(Fields, len(e.fields)+1)
+	for k, v := range e.fields {
+		fields[k] = v
+	}
+	fields["error"] = e.err.Error()
+	return fields
+}
+
 func getFileInfo() string {
 	if !showFileLine {
 		return ""
@@ -122,65 +252,437 @@ func getGoroutineID() string {
 	return fmt.Sprintf("(goroutine %s)", id)
 }
 
-func logWithTimestamp(prefix, msg string, level LogLevel) {
+// logWithFields builds the final Entry, fires any registered Hooks, and
+// dispatches the entry to every registered Sink. key identifies the call
+// site for sampling (normally the format string, unsubstituted); rate
+// limiting is per level only. key has no effect on the rendered line.
+func logWithFields(prefix, levelName, key, msg string, level LogLevel, fields Fields) {
 	if level > currentLogLevel {
 		return
 	}
 
-	timestamp := time.Now().UTC().Format("2006-01-02 15:04:05.000 UTC")
-	fileInfo := getFileInfo()
-	goroutineInfo := getGoroutineID()
+	// Rate limiting and sampling exist to tame high-volume Debug/Trace
+	// storms; they must not be able to silently drop a Panic/Error entry,
+	// since Panic always calls panic() regardless and hooks (e.g. a Sentry
+	// StackHook) depend on firing for every one of those to ship crash
+	// reports.
+	if level > ErrorLevel {
+		if !rateLimitAllow(level) {
+			return
+		}
+		if !sampleAllow(level, key) {
+			return
+		}
+	}
+
+	entry := Entry{
+		fields:        fields,
+		Time:          time.Now().UTC(),
+		Level:         level,
+		LevelName:     levelName,
+		Prefix:        prefix,
+		Message:       msg,
+		FileInfo:      getFileInfo(),
+		GoroutineInfo: getGoroutineID(),
+	}
+	fireHooks(entry)
+	dispatch(entry)
+}
+
+// formatFieldsText renders fields as space-separated key=value pairs for
+// text-mode output.
+func formatFieldsText(fields Fields) string {
+	parts := make([]string, 0, len(fields))
+	for k, v := range fields {
+		parts = append(parts, fmt.Sprintf("%s=%v", k, v))
+	}
+	return strings.Join(parts, " ")
+}
 
-	// Construct the log message
-	logMsg := fmt.Sprintf("%s [%s]", prefix, timestamp)
+// renderJSON builds the single-line JSON object emitted in FormatterJSON mode:
+// time, level, msg, file, goroutine, plus any merged fields.
+func renderJSON(levelName, msg, timestamp, fileInfo, goroutineInfo string, fields Fields) string {
+	obj := make(map[string]interface{}, len(fields)+5)
+	for k, v := range fields {
+		obj[k] = v
+	}
+	obj["time"] = timestamp
+	obj["level"] = levelName
+	obj["msg"] = msg
 	if fileInfo != "" {
-		logMsg += fmt.Sprintf(" [%s]", fileInfo)
+		obj["file"] = fileInfo
 	}
 	if goroutineInfo != "" {
-		logMsg += fmt.Sprintf(" %s", goroutineInfo)
+		obj["goroutine"] = goroutineInfo
+	}
+
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return fmt.Sprintf(`{"time":%q,"level":"error","msg":"clog: failed to marshal json log entry: %v"}`, timestamp, err)
+	}
+	return string(b)
+}
+
+// ansiPattern matches the escape sequences fatih/color wraps prefixes in, so
+// no-color sinks (files, plain writers) can render the same prefixes without
+// garbling non-terminal output.
+var ansiPattern = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+func stripANSI(s string) string {
+	return ansiPattern.ReplaceAllString(s, "")
+}
+
+// formatLine renders entry as prefix, timestamp, file info, goroutine info,
+// message, and fields — or, in
+// FormatterJSON mode, a single JSON object. colored controls whether ANSI
+// color codes are kept in the prefix.
+func formatLine(entry Entry, colored bool) string {
+	timestamp := entry.Time.Format("2006-01-02 15:04:05.000 UTC")
+
+	if currentFormatter == FormatterJSON {
+		return renderJSON(entry.LevelName, entry.Message, timestamp, entry.FileInfo, entry.GoroutineInfo, entry.fields)
+	}
+
+	prefix := entry.Prefix
+	if !colored {
+		prefix = stripANSI(prefix)
 	}
-	logMsg += fmt.Sprintf(" %s", msg)
 
-	fmt.Println(logMsg)
+	line := fmt.Sprintf("%s [%s]", prefix, timestamp)
+	if entry.FileInfo != "" {
+		line += fmt.Sprintf(" [%s]", entry.FileInfo)
+	}
+	if entry.GoroutineInfo != "" {
+		line += fmt.Sprintf(" %s", entry.GoroutineInfo)
+	}
+	line += fmt.Sprintf(" %s", entry.Message)
+	if len(entry.fields) > 0 {
+		line += " " + formatFieldsText(entry.fields)
+	}
+	return line
+}
+
+// Sink receives every log Entry that passes the global and per-sink level
+// filters. Implementations must be safe for concurrent use.
+type Sink interface {
+	Write(entry Entry) error
+}
+
+type sinkRegistration struct {
+	sink     Sink
+	minLevel LogLevel
+}
+
+var (
+	sinksMu sync.Mutex
+	sinks   = []sinkRegistration{{sink: NewStderrSink(), minLevel: TraceLevel}}
+)
+
+// AddSink registers sink to receive every entry at minLevel or more severe
+// (i.e. entry.Level <= minLevel, using the same ordering as SetLogLevel).
+func AddSink(sink Sink, minLevel LogLevel) {
+	sinksMu.Lock()
+	defer sinksMu.Unlock()
+	sinks = append(sinks, sinkRegistration{sink: sink, minLevel: minLevel})
+}
+
+// RemoveSink unregisters sink. Sinks are compared by identity, so pass the
+// same value (typically a pointer) that was given to AddSink.
+func RemoveSink(sink Sink) {
+	sinksMu.Lock()
+	defer sinksMu.Unlock()
+	filtered := sinks[:0]
+	for _, r := range sinks {
+		if r.sink != sink {
+			filtered = append(filtered, r)
+		}
+	}
+	sinks = filtered
+}
+
+// SetOutput replaces every registered sink with a single plain-text writer
+// sink targeting w, mirroring the standard log package's SetOutput.
+func SetOutput(w io.Writer) {
+	sinksMu.Lock()
+	defer sinksMu.Unlock()
+	sinks = []sinkRegistration{{sink: NewWriterSink(w), minLevel: TraceLevel}}
+}
+
+// dispatch fans entry out to every sink whose minLevel accepts it.
+func dispatch(entry Entry) {
+	sinksMu.Lock()
+	targets := make([]sinkRegistration, len(sinks))
+	copy(targets, sinks)
+	sinksMu.Unlock()
+
+	for _, r := range targets {
+		if entry.Level > r.minLevel {
+			continue
+		}
+		if err := r.sink.Write(entry); err != nil {
+			fmt.Fprintf(os.Stderr, "clog: sink write error: %v\n", err)
+		}
+	}
+}
+
+// StderrSink writes colored text lines to os.Stderr. It is the default sink.
+type StderrSink struct{}
+
+// NewStderrSink creates a StderrSink.
+func NewStderrSink() *StderrSink {
+	return &StderrSink{}
+}
+
+// Write implements Sink.
+func (s *StderrSink) Write(entry Entry) error {
+	_, err := fmt.Fprintln(os.Stderr, formatLine(entry, true))
+	return err
+}
+
+// WriterSink writes uncolored text (or JSON, under FormatterJSON) lines to
+// an arbitrary io.Writer.
+type WriterSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewWriterSink creates a WriterSink targeting w.
+func NewWriterSink(w io.Writer) *WriterSink {
+	return &WriterSink{w: w}
+}
+
+// Write implements Sink.
+func (s *WriterSink) Write(entry Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err := fmt.Fprintln(s.w, formatLine(entry, false))
+	return err
+}
+
+// RotatingFileSink writes log lines to Filename, rotating it once it grows
+// past MaxSizeMB (lumberjack-style). MaxAgeDays and MaxBackups bound how
+// many rotated backups are kept; zero means unbounded.
+type RotatingFileSink struct {
+	Filename   string
+	MaxSizeMB  int
+	MaxAgeDays int
+	MaxBackups int
+
+	mu   sync.Mutex
+	file *os.File
+	size int64
+}
+
+// NewRotatingFileSink creates a RotatingFileSink. maxSizeMB <= 0 defaults to
+// 100MB; maxAgeDays and maxBackups <= 0 mean no limit.
+func NewRotatingFileSink(filename string, maxSizeMB, maxAgeDays, maxBackups int) *RotatingFileSink {
+	return &RotatingFileSink{
+		Filename:   filename,
+		MaxSizeMB:  maxSizeMB,
+		MaxAgeDays: maxAgeDays,
+		MaxBackups: maxBackups,
+	}
+}
+
+// Write implements Sink.
+func (s *RotatingFileSink) Write(entry Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	line := formatLine(entry, false) + "\n"
+
+	if err := s.ensureOpen(); err != nil {
+		return err
+	}
+	if s.size+int64(len(line)) > s.maxSizeBytes() {
+		if err := s.rotate(); err != nil {
+			return err
+		}
+	}
+
+	n, err := s.file.WriteString(line)
+	s.size += int64(n)
+	return err
+}
+
+func (s *RotatingFileSink) maxSizeBytes() int64 {
+	maxSize := s.MaxSizeMB
+	if maxSize <= 0 {
+		maxSize = 100
+	}
+	return int64(maxSize) * 1024 * 1024
+}
+
+func (s *RotatingFileSink) ensureOpen() error {
+	if s.file != nil {
+		return nil
+	}
+	f, err := os.OpenFile(s.Filename, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	s.file = f
+	s.size = info.Size()
+	return nil
+}
+
+func (s *RotatingFileSink) rotate() error {
+	if s.file != nil {
+		s.file.Close()
+		s.file = nil
+	}
+	if _, err := os.Stat(s.Filename); err == nil {
+		backup := fmt.Sprintf("%s.%s", s.Filename, time.Now().UTC().Format("20060102T150405.000000000"))
+		if err := os.Rename(s.Filename, backup); err != nil {
+			return err
+		}
+	}
+	if err := s.pruneBackups(); err != nil {
+		return err
+	}
+	return s.ensureOpen()
+}
+
+// pruneBackups removes rotated backups older than MaxAgeDays and, beyond
+// that, any past the newest MaxBackups.
+func (s *RotatingFileSink) pruneBackups() error {
+	matches, err := filepath.Glob(s.Filename + ".*")
+	if err != nil {
+		return err
+	}
+	sort.Strings(matches) // timestamp suffix sorts chronologically
+
+	if s.MaxAgeDays > 0 {
+		cutoff := time.Now().UTC().AddDate(0, 0, -s.MaxAgeDays)
+		kept := matches[:0]
+		for _, m := range matches {
+			if info, err := os.Stat(m); err == nil && info.ModTime().UTC().Before(cutoff) {
+				os.Remove(m)
+				continue
+			}
+			kept = append(kept, m)
+		}
+		matches = kept
+	}
+
+	if s.MaxBackups > 0 && len(matches) > s.MaxBackups {
+		for _, m := range matches[:len(matches)-s.MaxBackups] {
+			os.Remove(m)
+		}
+	}
+	return nil
+}
+
+// AsyncSink buffers entries on a channel and writes them to an underlying
+// sink from a background goroutine, so hot-path callers never block on
+// slow I/O. If the buffer fills up, entries are dropped.
+type AsyncSink struct {
+	sink   Sink
+	buffer chan Entry
+	done   chan struct{}
+
+	mu     sync.RWMutex
+	closed bool
+}
+
+// NewAsyncSink wraps sink with a channel buffer of the given size and starts
+// the background flushing goroutine.
+func NewAsyncSink(sink Sink, bufferSize int) *AsyncSink {
+	s := &AsyncSink{
+		sink:   sink,
+		buffer: make(chan Entry, bufferSize),
+		done:   make(chan struct{}),
+	}
+	go s.run()
+	return s
+}
+
+func (s *AsyncSink) run() {
+	defer close(s.done)
+	for entry := range s.buffer {
+		if err := s.sink.Write(entry); err != nil {
+			fmt.Fprintf(os.Stderr, "clog: async sink write error: %v\n", err)
+		}
+	}
+}
+
+// Write implements Sink. It never blocks: if the buffer is full, or the
+// sink has been Closed, the entry is dropped and an error is returned.
+func (s *AsyncSink) Write(entry Entry) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return fmt.Errorf("clog: async sink closed, dropping entry")
+	}
+
+	select {
+	case s.buffer <- entry:
+		return nil
+	default:
+		return fmt.Errorf("clog: async sink buffer full, dropping entry")
+	}
+}
+
+// Close stops accepting new entries and blocks until the buffered ones have
+// been flushed to the underlying sink. Safe to call concurrently with Write,
+// and idempotent.
+func (s *AsyncSink) Close() error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil
+	}
+	s.closed = true
+	close(s.buffer)
+	s.mu.Unlock()
+
+	<-s.done
+	return nil
 }
 
 // Existing methods with log level support
 func Info(format string, args ...interface{}) {
-	logWithTimestamp(infoPrefix, fmt.Sprintf(format, args...), InfoLevel)
+	logWithFields(infoPrefix, "info", format, fmt.Sprintf(format, args...), InfoLevel, nil)
 }
 
 func Success(format string, args ...interface{}) {
-	logWithTimestamp(successPrefix, fmt.Sprintf(format, args...), InfoLevel)
+	logWithFields(successPrefix, "success", format, fmt.Sprintf(format, args...), InfoLevel, nil)
 }
 
 func Init(format string, args ...interface{}) {
-	logWithTimestamp(initPrefix, fmt.Sprintf(format, args...), InfoLevel)
+	logWithFields(initPrefix, "init", format, fmt.Sprintf(format, args...), InfoLevel, nil)
 }
 
 func Config(format string, args ...interface{}) {
-	logWithTimestamp(configPrefix, fmt.Sprintf(format, args...), InfoLevel)
+	logWithFields(configPrefix, "config", format, fmt.Sprintf(format, args...), InfoLevel, nil)
 }
 
 func Warning(format string, args ...interface{}) {
-	logWithTimestamp(warningPrefix, fmt.Sprintf(format, args...), WarningLevel)
+	logWithFields(warningPrefix, "warning", format, fmt.Sprintf(format, args...), WarningLevel, nil)
 }
 
 func Error(format string, args ...interface{}) {
-	logWithTimestamp(errorPrefix, fmt.Sprintf(format, args...), ErrorLevel)
+	logWithFields(errorPrefix, "error", format, fmt.Sprintf(format, args...), ErrorLevel, nil)
 }
 
 // New debug methods
 func Debug(format string, args ...interface{}) {
-	logWithTimestamp(debugPrefix, fmt.Sprintf(format, args...), DebugLevel)
+	logWithFields(debugPrefix, "debug", format, fmt.Sprintf(format, args...), DebugLevel, nil)
 }
 
-func Trace(format string, args ...interface{}) {
-	logWithTimestamp(tracePrefix, fmt.Sprintf(format, args...), TraceLevel)
+func Tracef(format string, args ...interface{}) {
+	logWithFields(tracePrefix, "trace", format, fmt.Sprintf(format, args...), TraceLevel, nil)
 }
 
 func Panic(format string, args ...interface{}) {
 	msg := fmt.Sprintf(format, args...)
-	logWithTimestamp(panicPrefix, msg, PanicLevel)
+	logWithFields(panicPrefix, "panic", format, msg, PanicLevel, nil)
 	panic(msg)
 }
 
@@ -189,5 +691,43 @@ func Metric(name string, value interface{}, tags ...string) {
 	if len(tags) > 0 {
 		tagStr = fmt.Sprintf(" [%s]", strings.Join(tags, ", "))
 	}
-	logWithTimestamp(metricPrefix, fmt.Sprintf("%s: %v%s", name, value, tagStr), InfoLevel)
+	logWithFields(metricPrefix, "metric", name, fmt.Sprintf("%s: %v%s", name, value, tagStr), InfoLevel, nil)
+}
+
+// Info logs msg at InfoLevel with the Entry's structured fields merged in.
+func (e *Entry) Info(format string, args ...interface{}) {
+	logWithFields(infoPrefix, "info", format, fmt.Sprintf(format, args...), InfoLevel, e.mergedFields())
+}
+
+// Success logs msg at InfoLevel with the Entry's structured fields merged in.
+func (e *Entry) Success(format string, args ...interface{}) {
+	logWithFields(successPrefix, "success", format, fmt.Sprintf(format, args...), InfoLevel, e.mergedFields())
+}
+
+// Warning logs msg at WarningLevel with the Entry's structured fields merged in.
+func (e *Entry) Warning(format string, args ...interface{}) {
+	logWithFields(warningPrefix, "warning", format, fmt.Sprintf(format, args...), WarningLevel, e.mergedFields())
+}
+
+// Error logs msg at ErrorLevel with the Entry's structured fields merged in.
+func (e *Entry) Error(format string, args ...interface{}) {
+	logWithFields(errorPrefix, "error", format, fmt.Sprintf(format, args...), ErrorLevel, e.mergedFields())
+}
+
+// Debug logs msg at DebugLevel with the Entry's structured fields merged in.
+func (e *Entry) Debug(format string, args ...interface{}) {
+	logWithFields(debugPrefix, "debug", format, fmt.Sprintf(format, args...), DebugLevel, e.mergedFields())
+}
+
+// Tracef logs msg at TraceLevel with the Entry's structured fields merged in.
+func (e *Entry) Tracef(format string, args ...interface{}) {
+	logWithFields(tracePrefix, "trace", format, fmt.Sprintf(format, args...), TraceLevel, e.mergedFields())
+}
+
+// Panic logs msg at PanicLevel with the Entry's structured fields merged in,
+// then panics.
+func (e *Entry) Panic(format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	logWithFields(panicPrefix, "panic", format, msg, PanicLevel, e.mergedFields())
+	panic(msg)
 }
@@ -0,0 +1,82 @@
+package clog
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"sync"
+)
+
+// Hook receives every Entry whose level matches one of Levels(), right
+// after the entry is finalized but before it reaches any Sink. It lets
+// callers forward log lines to external systems (Sentry, Airbrake, a
+// metrics endpoint) without clog depending on them.
+type Hook interface {
+	Levels() []LogLevel
+	Fire(entry Entry) error
+}
+
+var (
+	hooksMu sync.Mutex
+	hooks   []Hook
+)
+
+// AddHook registers hook to be fired for every entry at one of its Levels().
+func AddHook(hook Hook) {
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+	hooks = append(hooks, hook)
+}
+
+// fireHooks runs every registered hook whose Levels() includes entry.Level.
+func fireHooks(entry Entry) {
+	hooksMu.Lock()
+	targets := make([]Hook, len(hooks))
+	copy(targets, hooks)
+	hooksMu.Unlock()
+
+	for _, h := range targets {
+		for _, lvl := range h.Levels() {
+			if lvl == entry.Level {
+				if err := h.Fire(entry); err != nil {
+					fmt.Fprintf(os.Stderr, "clog: hook fire error: %v\n", err)
+				}
+				break
+			}
+		}
+	}
+}
+
+// StackHook fires on ErrorLevel and PanicLevel entries, capturing the
+// current goroutine's stack trace and forwarding the entry (message,
+// fields, and file:line are all on Entry) plus the stack to callback.
+type StackHook struct {
+	Callback func(entry Entry, stack string)
+}
+
+// NewStackHook creates a StackHook that invokes callback for every
+// ErrorLevel/PanicLevel entry, with the capturing goroutine's stack trace.
+func NewStackHook(callback func(entry Entry, stack string)) *StackHook {
+	return &StackHook{Callback: callback}
+}
+
+// Levels implements Hook.
+func (h *StackHook) Levels() []LogLevel {
+	return []LogLevel{PanicLevel, ErrorLevel}
+}
+
+// Fire implements Hook.
+func (h *StackHook) Fire(entry Entry) error {
+	if h.Callback == nil {
+		return nil
+	}
+	buf := make([]byte, 4096)
+	for {
+		n := runtime.Stack(buf, false)
+		if n < len(buf) {
+			h.Callback(entry, string(buf[:n]))
+			return nil
+		}
+		buf = make([]byte, len(buf)*2)
+	}
+}
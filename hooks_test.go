@@ -0,0 +1,53 @@
+package clog
+
+import (
+	"testing"
+	"time"
+)
+
+func resetHooksForTest() {
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+	hooks = nil
+}
+
+// TestHooksFireUnderSamplingForErrorAndPanic guards against sampling/rate
+// limiting (meant to tame Debug/Trace storms) silently suppressing hooks for
+// Error/Panic entries, which would mean crash reports never reach an
+// external system like Sentry.
+func TestHooksFireUnderSamplingForErrorAndPanic(t *testing.T) {
+	// Aggressive sampling: without the ErrorLevel/PanicLevel exemption in
+	// logWithFields, only the very first Error and the very first Panic
+	// call would make it past sampleAllow.
+	SetSampler(time.Hour, 1, 1000000)
+	defer SetSampler(0, 0, 0)
+
+	defer resetHooksForTest()
+	var errorFires, panicFires int
+	AddHook(NewStackHook(func(entry Entry, stack string) {
+		switch entry.Level {
+		case ErrorLevel:
+			errorFires++
+		case PanicLevel:
+			panicFires++
+		}
+	}))
+
+	const n = 20
+	for i := 0; i < n; i++ {
+		Error("regression test error %d", i)
+	}
+	for i := 0; i < n; i++ {
+		func() {
+			defer func() { _ = recover() }()
+			Panic("regression test panic %d", i)
+		}()
+	}
+
+	if errorFires != n {
+		t.Errorf("errorFires = %d, want %d (sampling must not suppress hooks for ErrorLevel)", errorFires, n)
+	}
+	if panicFires != n {
+		t.Errorf("panicFires = %d, want %d (sampling must not suppress hooks for PanicLevel)", panicFires, n)
+	}
+}
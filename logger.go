@@ -0,0 +1,183 @@
+package clog
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Logger is a scoped logger: unlike the package-level Info/Debug/Error/...
+// functions, which share global state, a Logger carries its own component
+// name and structured fields. Create one with NewLogger, narrow it with
+// Named, and attach it to a context with NewContext so that HTTP/gRPC
+// middleware can set a request ID once and have every downstream
+// clog.FromContext(ctx).Info(...) call carry it.
+type Logger struct {
+	component string
+	fields    Fields
+}
+
+// NewLogger creates an unscoped Logger with no component name or fields.
+func NewLogger() *Logger {
+	return &Logger{fields: Fields{}}
+}
+
+// Named returns a child Logger with name appended to the component tag
+// (dot-separated, e.g. "server.auth"), inheriting the parent's fields.
+func (l *Logger) Named(name string) *Logger {
+	component := name
+	if l.component != "" {
+		component = l.component + "." + name
+	}
+	fields := make(Fields, len(l.fields))
+	for k, v := range l.fields {
+		fields[k] = v
+	}
+	return &Logger{component: component, fields: fields}
+}
+
+// entry seeds a new Entry with the Logger's component tag and fields.
+func (l *Logger) entry() *Entry {
+	fields := make(Fields, len(l.fields)+1)
+	for k, v := range l.fields {
+		fields[k] = v
+	}
+	if l.component != "" {
+		fields["component"] = l.component
+	}
+	return &Entry{fields: fields}
+}
+
+// WithField starts an Entry scoped to this Logger, carrying a single
+// structured field.
+func (l *Logger) WithField(key string, value interface{}) *Entry {
+	return l.entry().WithField(key, value)
+}
+
+// WithFields starts an Entry scoped to this Logger, carrying the given
+// structured fields.
+func (l *Logger) WithFields(fields Fields) *Entry {
+	return l.entry().WithFields(fields)
+}
+
+// WithError starts an Entry scoped to this Logger, carrying err.
+func (l *Logger) WithError(err error) *Entry {
+	return l.entry().WithError(err)
+}
+
+// WithContext starts an Entry scoped to this Logger, carrying the OTel
+// trace_id/span_id from ctx (if any) and any registered baggage keys present
+// on ctx.
+func (l *Logger) WithContext(ctx context.Context) *Entry {
+	e := l.entry()
+
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		e.fields["trace_id"] = sc.TraceID().String()
+		e.fields["span_id"] = sc.SpanID().String()
+	}
+
+	bag := baggage.FromContext(ctx)
+	for _, key := range registeredBaggageKeys() {
+		if member := bag.Member(key); member.Key() != "" {
+			e.fields[key] = member.Value()
+		}
+	}
+
+	return e
+}
+
+// Info logs msg at InfoLevel, tagged with this Logger's component and
+// fields. Calls logWithFields directly (rather than through Entry) to keep
+// the caller's file:line at the same stack depth as the package-level Info.
+func (l *Logger) Info(format string, args ...interface{}) {
+	logWithFields(infoPrefix, "info", format, fmt.Sprintf(format, args...), InfoLevel, l.entry().mergedFields())
+}
+
+// Success logs msg at InfoLevel, tagged with this Logger's component and fields.
+func (l *Logger) Success(format string, args ...interface{}) {
+	logWithFields(successPrefix, "success", format, fmt.Sprintf(format, args...), InfoLevel, l.entry().mergedFields())
+}
+
+// Warning logs msg at WarningLevel, tagged with this Logger's component and fields.
+func (l *Logger) Warning(format string, args ...interface{}) {
+	logWithFields(warningPrefix, "warning", format, fmt.Sprintf(format, args...), WarningLevel, l.entry().mergedFields())
+}
+
+// Error logs msg at ErrorLevel, tagged with this Logger's component and fields.
+func (l *Logger) Error(format string, args ...interface{}) {
+	logWithFields(errorPrefix, "error", format, fmt.Sprintf(format, args...), ErrorLevel, l.entry().mergedFields())
+}
+
+// Debug logs msg at DebugLevel, tagged with this Logger's component and fields.
+func (l *Logger) Debug(format string, args ...interface{}) {
+	logWithFields(debugPrefix, "debug", format, fmt.Sprintf(format, args...), DebugLevel, l.entry().mergedFields())
+}
+
+// Tracef logs msg at TraceLevel, tagged with this Logger's component and fields.
+func (l *Logger) Tracef(format string, args ...interface{}) {
+	logWithFields(tracePrefix, "trace", format, fmt.Sprintf(format, args...), TraceLevel, l.entry().mergedFields())
+}
+
+// Panic logs msg at PanicLevel, tagged with this Logger's component and
+// fields, then panics.
+func (l *Logger) Panic(format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	logWithFields(panicPrefix, "panic", format, msg, PanicLevel, l.entry().mergedFields())
+	panic(msg)
+}
+
+var defaultLogger = NewLogger()
+
+type loggerContextKey struct{}
+
+// NewContext returns a copy of ctx carrying logger, retrievable with
+// FromContext.
+func NewContext(ctx context.Context, logger *Logger) context.Context {
+	return context.WithValue(ctx, loggerContextKey{}, logger)
+}
+
+// FromContext returns the Logger attached to ctx by NewContext, or the
+// package's default (unscoped) Logger if none was attached.
+func FromContext(ctx context.Context) *Logger {
+	if logger, ok := ctx.Value(loggerContextKey{}).(*Logger); ok {
+		return logger
+	}
+	return defaultLogger
+}
+
+// WithContext starts an Entry from the Logger attached to ctx (or the
+// default Logger, if none was attached via NewContext), carrying the OTel
+// trace_id/span_id from ctx (if any) and any registered baggage keys present
+// on ctx. Shorthand for FromContext(ctx).WithContext(ctx).
+func WithContext(ctx context.Context) *Entry {
+	return FromContext(ctx).WithContext(ctx)
+}
+
+var (
+	baggageKeysMu sync.Mutex
+	baggageKeys   []string
+)
+
+// RegisterBaggageKey adds key to the set of OTel baggage members that
+// WithContext/Logger.WithContext copy onto every emitted line.
+func RegisterBaggageKey(key string) {
+	baggageKeysMu.Lock()
+	defer baggageKeysMu.Unlock()
+	for _, k := range baggageKeys {
+		if k == key {
+			return
+		}
+	}
+	baggageKeys = append(baggageKeys, key)
+}
+
+func registeredBaggageKeys() []string {
+	baggageKeysMu.Lock()
+	defer baggageKeysMu.Unlock()
+	keys := make([]string, len(baggageKeys))
+	copy(keys, baggageKeys)
+	return keys
+}
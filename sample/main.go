@@ -27,7 +27,7 @@ func main() {
 
 	// Debug and trace information
 	clog.Debug("Connected to database with timeout: %v", 30*time.Second)
-	clog.Trace("Establishing connection pool with size: %d", 10)
+	clog.Tracef("Establishing connection pool with size: %d", 10)
 
 	// Performance metrics
 	clog.Metric("active_connections", 42, "db=postgres", "host=primary")
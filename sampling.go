@@ -0,0 +1,215 @@
+package clog
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+	"unsafe"
+)
+
+// sampleKey identifies a call site for the purposes of sampling: its level
+// plus the address of the format string's backing data. Since format
+// strings are almost always literals, repeated calls from the same call
+// site share the same backing array and therefore the same key, without
+// needing to hash or compare the string itself on the hot path.
+type sampleKey struct {
+	level LogLevel
+	ptr   uintptr
+}
+
+func stringDataPointer(s string) uintptr {
+	if len(s) == 0 {
+		return 0
+	}
+	return uintptr(unsafe.Pointer(unsafe.StringData(s)))
+}
+
+// sampleShardCount shards sampler counters across multiple sync.Maps so that
+// hot-path callers hitting different call sites don't contend on the same
+// bucket.
+const sampleShardCount = 16
+
+var sampleShards [sampleShardCount]sync.Map
+
+func sampleShardFor(key sampleKey) *sync.Map {
+	h := uint64(key.ptr)*31 + uint64(key.level)
+	return &sampleShards[h%sampleShardCount]
+}
+
+var (
+	samplerMu         sync.Mutex
+	samplerEnabled    bool
+	samplerFirst      int
+	samplerThereafter int
+	samplerStop       chan struct{}
+)
+
+var droppedCount atomic.Uint64
+
+// SetSampler enables zap-style sampling: within each tick window, keyed by
+// (level, call site), the first occurrences are logged verbatim, then only
+// every thereafter-th occurrence is logged and the rest are dropped. Dropped
+// lines are counted and periodically surfaced via Metric("clog_dropped", n).
+// ErrorLevel and PanicLevel entries are never sampled.
+// Passing tick <= 0 or thereafter <= 0 disables sampling.
+func SetSampler(tick time.Duration, first, thereafter int) {
+	samplerMu.Lock()
+	defer samplerMu.Unlock()
+
+	if samplerStop != nil {
+		close(samplerStop)
+		samplerStop = nil
+	}
+
+	samplerFirst = first
+	samplerThereafter = thereafter
+	samplerEnabled = tick > 0 && thereafter > 0
+	clearSampleShards()
+
+	if !samplerEnabled {
+		return
+	}
+
+	stop := make(chan struct{})
+	samplerStop = stop
+	go runSampler(tick, stop)
+}
+
+func runSampler(tick time.Duration, stop chan struct{}) {
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			resetSampleShards()
+		case <-stop:
+			return
+		}
+	}
+}
+
+// clearSampleShards empties every shard's counters, with no side effects.
+// Used by SetSampler, which must not call Metric (and thus re-enter
+// sampleAllow) while still holding samplerMu.
+func clearSampleShards() {
+	for i := range sampleShards {
+		sampleShards[i].Range(func(key, _ interface{}) bool {
+			sampleShards[i].Delete(key)
+			return true
+		})
+	}
+}
+
+// resetSampleShards clears every shard's counters and reports whatever was
+// dropped since the last reset. Only called from the ticker goroutine in
+// runSampler, which does not hold samplerMu.
+func resetSampleShards() {
+	clearSampleShards()
+	if dropped := droppedCount.Swap(0); dropped > 0 {
+		Metric("clog_dropped", dropped)
+	}
+}
+
+// sampleAllow reports whether a line for (level, format) should be logged,
+// given the current sampler configuration.
+func sampleAllow(level LogLevel, format string) bool {
+	samplerMu.Lock()
+	enabled := samplerEnabled
+	first := samplerFirst
+	thereafter := samplerThereafter
+	samplerMu.Unlock()
+
+	if !enabled {
+		return true
+	}
+
+	key := sampleKey{level: level, ptr: stringDataPointer(format)}
+	shard := sampleShardFor(key)
+	counterIface, _ := shard.LoadOrStore(key, new(uint64))
+	counter := counterIface.(*uint64)
+	n := atomic.AddUint64(counter, 1)
+
+	if n <= uint64(first) {
+		return true
+	}
+	if (n-uint64(first))%uint64(thereafter) == 0 {
+		return true
+	}
+	droppedCount.Add(1)
+	return false
+}
+
+// tokenBucket is a simple token-bucket rate limiter: tokens accumulate at
+// refillPerSecond up to burst capacity, and each allowed call consumes one.
+type tokenBucket struct {
+	mu              sync.Mutex
+	tokens          float64
+	burst           float64
+	refillPerSecond float64
+	last            time.Time
+}
+
+func newTokenBucket(refillPerSecond float64, burst int) *tokenBucket {
+	return &tokenBucket{
+		tokens:          float64(burst),
+		burst:           float64(burst),
+		refillPerSecond: refillPerSecond,
+		last:            time.Now(),
+	}
+}
+
+func (b *tokenBucket) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.tokens += now.Sub(b.last).Seconds() * b.refillPerSecond
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+var (
+	rateLimitersMu sync.Mutex
+	rateLimiters   = map[LogLevel]*tokenBucket{}
+)
+
+// SetRateLimit configures a token-bucket rate limiter for level: up to burst
+// calls may pass immediately, refilling at ratePerSecond calls/second
+// thereafter; calls beyond that budget are dropped and counted toward
+// Metric("clog_dropped", ...), which is only emitted on the sampler's tick
+// while a sampler is enabled (see SetSampler). ErrorLevel and PanicLevel
+// entries are never rate limited, so a limiter set for them has no effect.
+// Passing ratePerSecond <= 0 removes any limiter for level.
+func SetRateLimit(level LogLevel, ratePerSecond float64, burst int) {
+	rateLimitersMu.Lock()
+	defer rateLimitersMu.Unlock()
+
+	if ratePerSecond <= 0 {
+		delete(rateLimiters, level)
+		return
+	}
+	rateLimiters[level] = newTokenBucket(ratePerSecond, burst)
+}
+
+func rateLimitAllow(level LogLevel) bool {
+	rateLimitersMu.Lock()
+	bucket := rateLimiters[level]
+	rateLimitersMu.Unlock()
+
+	if bucket == nil {
+		return true
+	}
+	if bucket.allow() {
+		return true
+	}
+	droppedCount.Add(1)
+	return false
+}
@@ -0,0 +1,40 @@
+package clog
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSampleAllowFirstThenThereafter(t *testing.T) {
+	SetSampler(time.Hour, 2, 3) // long tick: the window must not reset mid-test
+	defer SetSampler(0, 0, 0)   // disable sampling again for other tests
+
+	const format = "sampler_test: occurrence %d"
+
+	var allowed, dropped int
+	for i := 0; i < 10; i++ {
+		if sampleAllow(DebugLevel, format) {
+			allowed++
+		} else {
+			dropped++
+		}
+	}
+
+	// first=2 verbatim (calls 1-2), then every 3rd call thereafter
+	// (calls 5 and 8, i.e. the 3rd and 6th after the first 2) passes: 4 allowed, 6 dropped.
+	if allowed != 4 {
+		t.Errorf("allowed = %d, want 4", allowed)
+	}
+	if dropped != 6 {
+		t.Errorf("dropped = %d, want 6", dropped)
+	}
+}
+
+func TestSampleAllowDisabledByDefault(t *testing.T) {
+	const format = "sampler_test: disabled %d"
+	for i := 0; i < 5; i++ {
+		if !sampleAllow(InfoLevel, format) {
+			t.Fatalf("call %d: expected sampling to be a no-op when not configured", i)
+		}
+	}
+}
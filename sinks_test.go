@@ -0,0 +1,85 @@
+package clog
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type sinkFunc func(Entry) error
+
+func (f sinkFunc) Write(e Entry) error { return f(e) }
+
+func TestAsyncSinkConcurrentWriteClose(t *testing.T) {
+	var writes int64
+	sink := NewAsyncSink(sinkFunc(func(Entry) error {
+		atomic.AddInt64(&writes, 1)
+		return nil
+	}), 8)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = sink.Write(Entry{})
+		}()
+	}
+
+	// Close races against the in-flight writers above; it must never panic
+	// with "send on closed channel", and must be safe to call more than once.
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		_ = sink.Close()
+	}()
+	go func() {
+		defer wg.Done()
+		_ = sink.Close()
+	}()
+
+	wg.Wait()
+}
+
+func TestRotatingFileSinkRotatesAndPrunes(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	// Smallest representable size (1MB) so the test can cross it with a
+	// reasonable number of small writes.
+	sink := NewRotatingFileSink(path, 1, 0, 2)
+
+	entry := Entry{
+		Time:      time.Now(),
+		Level:     InfoLevel,
+		LevelName: "info",
+		Prefix:    infoPrefix,
+		Message:   strings.Repeat("x", 100),
+	}
+
+	const writeCount = 40000
+	for i := 0; i < writeCount; i++ {
+		if err := sink.Write(entry); err != nil {
+			t.Fatalf("write %d: %v", i, err)
+		}
+	}
+
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("expected current log file to exist: %v", err)
+	}
+
+	backups, err := filepath.Glob(path + ".*")
+	if err != nil {
+		t.Fatalf("glob backups: %v", err)
+	}
+	if len(backups) == 0 {
+		t.Fatalf("expected at least one rotation to have occurred")
+	}
+	if len(backups) > 2 {
+		t.Fatalf("expected MaxBackups=2 to be enforced, got %d backups: %v", len(backups), backups)
+	}
+}
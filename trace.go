@@ -0,0 +1,53 @@
+package clog
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+var (
+	slowThresholdMu sync.Mutex
+	slowThreshold   = 100 * time.Millisecond
+)
+
+// SetSlowThreshold sets the elapsed-time threshold above which Trace emits
+// WarningLevel instead of DebugLevel. Default 100ms.
+func SetSlowThreshold(d time.Duration) {
+	slowThresholdMu.Lock()
+	defer slowThresholdMu.Unlock()
+	slowThreshold = d
+}
+
+func getSlowThreshold() time.Duration {
+	slowThresholdMu.Lock()
+	defer slowThresholdMu.Unlock()
+	return slowThreshold
+}
+
+// Trace logs a single timed operation — a DB query, an HTTP call, anything
+// instrumented with a start time — modeled on GORM's logger callback. fn is
+// called to obtain a human-readable description and a row/item count; its
+// result, the elapsed time since begin, and the caller's file:line are
+// merged into the emitted line. The level is chosen from the outcome:
+// ErrorLevel if err != nil, WarningLevel if elapsed exceeds SlowThreshold
+// (see SetSlowThreshold), otherwise DebugLevel.
+func Trace(begin time.Time, fn func() (msg string, rowsOrCount int64), err error) {
+	elapsed := time.Since(begin)
+	msg, rows := fn()
+
+	prefix, levelName, level := debugPrefix, "debug", DebugLevel
+	switch {
+	case err != nil:
+		prefix, levelName, level = errorPrefix, "error", ErrorLevel
+	case elapsed > getSlowThreshold():
+		prefix, levelName, level = warningPrefix, "warning", WarningLevel
+	}
+
+	line := fmt.Sprintf("%s [%.3fms] [rows:%d]", msg, float64(elapsed.Microseconds())/1000, rows)
+	if err != nil {
+		line += fmt.Sprintf(" error: %v", err)
+	}
+
+	logWithFields(prefix, levelName, msg, line, level, nil)
+}